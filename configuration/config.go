@@ -0,0 +1,36 @@
+// Package configuration holds the scanner's top-level settings: which AWS
+// profile to assume, how aggressively to fan out per-bucket API calls, which
+// accounts to cover in an Organization-wide run, and where to find the rule
+// suppression file.
+package configuration
+
+// Account identifies one member account to scan via AssumeRole. It carries
+// resource.Account's fields except MFASerial, without resource depending
+// back on this package.
+type Account struct {
+	AccountID  string
+	RoleARN    string
+	ExternalID string
+}
+
+// Config is the top-level scanner configuration, shared by single-account
+// and Organization-wide runs.
+type Config struct {
+	// Profile is the AWS CLI profile used to build the caller's session.
+	Profile string
+
+	// MaxConcurrency bounds the worker pool used for per-bucket API
+	// fan-out. Zero or negative leaves the choice to the caller's own
+	// default.
+	MaxConcurrency int
+
+	// Accounts lists the member accounts to scan in an Organization-wide
+	// run. Empty means the caller supplies accounts explicitly, e.g. via
+	// resource.DiscoverOrganizationAccounts.
+	Accounts []Account
+
+	// RulesConfigPath is the path to the YAML file suppressing or
+	// customizing built-in rules, as loaded by resource.LoadRulesConfig.
+	// Empty disables suppression.
+	RulesConfigPath string
+}
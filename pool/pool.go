@@ -0,0 +1,45 @@
+// Package pool provides a bounded worker pool and a retry helper for
+// rate-limit-aware AWS API calls, used in place of unbounded "one goroutine
+// per bucket" fan-out.
+package pool
+
+import "context"
+
+// Pool bounds how many submitted tasks may run concurrently.
+type Pool struct {
+	tokens chan struct{}
+}
+
+// New returns a Pool allowing at most maxConcurrency tasks to run at once.
+// A non-positive maxConcurrency is treated as 1.
+func New(maxConcurrency int) *Pool {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	return &Pool{tokens: make(chan struct{}, maxConcurrency)}
+}
+
+// Acquire blocks until a slot is free, or returns ctx.Err() if ctx is
+// cancelled first.
+func (p *Pool) Acquire(ctx context.Context) error {
+	select {
+	case p.tokens <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot acquired with Acquire.
+func (p *Pool) Release() {
+	<-p.tokens
+}
+
+// Do runs fn after acquiring a slot, releasing it again once fn returns.
+func (p *Pool) Do(ctx context.Context, fn func() error) error {
+	if err := p.Acquire(ctx); err != nil {
+		return err
+	}
+	defer p.Release()
+	return fn()
+}
@@ -0,0 +1,77 @@
+package pool
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// RetryConfig controls the decorrelated-jitter backoff used by Retry.
+type RetryConfig struct {
+	Base           time.Duration
+	Cap            time.Duration
+	MaxAttempts    int
+	RetryableCodes map[string]bool
+}
+
+// DefaultRetryConfig matches AWS's published guidance for decorrelated
+// jitter backoff (sleep = min(cap, random(base, prev*3))), starting at
+// base=100ms and capped at 20s, and retries the throttling errors the S3
+// API is known to return under load.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		Base:        100 * time.Millisecond,
+		Cap:         20 * time.Second,
+		MaxAttempts: 5,
+		RetryableCodes: map[string]bool{
+			"Throttling":           true,
+			"SlowDown":             true,
+			"RequestLimitExceeded": true,
+		},
+	}
+}
+
+// Retry calls fn, retrying with decorrelated-jitter backoff while fn returns
+// an awserr.Error whose code is in cfg.RetryableCodes, up to cfg.MaxAttempts
+// total attempts. Any other error - or ctx being cancelled - is returned
+// immediately.
+func Retry(ctx context.Context, cfg RetryConfig, fn func() error) error {
+	sleep := cfg.Base
+	var err error
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		aerr, ok := err.(awserr.Error)
+		if !ok || !cfg.RetryableCodes[aerr.Code()] {
+			return err
+		}
+		if attempt == cfg.MaxAttempts {
+			break
+		}
+		sleep = nextSleep(cfg.Base, cfg.Cap, sleep)
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// nextSleep computes the next decorrelated-jitter delay:
+// min(cap, random_between(base, prev*3)).
+func nextSleep(base, cap, prev time.Duration) time.Duration {
+	upper := int64(prev) * 3
+	if upper <= int64(base) {
+		upper = int64(base) + 1
+	}
+	next := base + time.Duration(rand.Int63n(upper-int64(base)+1))
+	if next > cap {
+		next = cap
+	}
+	return next
+}
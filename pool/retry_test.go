@@ -0,0 +1,130 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestRetrySucceedsWithoutRetrying(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), DefaultRetryConfig(), func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Retry() = %v, want nil", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryReturnsNonRetryableErrorImmediately(t *testing.T) {
+	calls := 0
+	want := errors.New("boom")
+	err := Retry(context.Background(), DefaultRetryConfig(), func() error {
+		calls++
+		return want
+	})
+	if err != want {
+		t.Fatalf("Retry() = %v, want %v", err, want)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (non-retryable error shouldn't retry)", calls)
+	}
+}
+
+func TestRetryRetriesRetryableCodeUntilSuccess(t *testing.T) {
+	cfg := RetryConfig{
+		Base:           time.Millisecond,
+		Cap:            10 * time.Millisecond,
+		MaxAttempts:    5,
+		RetryableCodes: map[string]bool{"Throttling": true},
+	}
+	calls := 0
+	err := Retry(context.Background(), cfg, func() error {
+		calls++
+		if calls < 3 {
+			return awserr.New("Throttling", "slow down", nil)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Retry() = %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	cfg := RetryConfig{
+		Base:           time.Millisecond,
+		Cap:            10 * time.Millisecond,
+		MaxAttempts:    3,
+		RetryableCodes: map[string]bool{"Throttling": true},
+	}
+	calls := 0
+	retryable := awserr.New("Throttling", "slow down", nil)
+	err := Retry(context.Background(), cfg, func() error {
+		calls++
+		return retryable
+	})
+	if err != retryable {
+		t.Fatalf("Retry() = %v, want %v", err, retryable)
+	}
+	if calls != cfg.MaxAttempts {
+		t.Errorf("calls = %d, want %d", calls, cfg.MaxAttempts)
+	}
+}
+
+func TestRetryStopsOnContextCancellation(t *testing.T) {
+	cfg := RetryConfig{
+		Base:           50 * time.Millisecond,
+		Cap:            time.Second,
+		MaxAttempts:    10,
+		RetryableCodes: map[string]bool{"Throttling": true},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Retry(ctx, cfg, func() error {
+		calls++
+		if calls == 1 {
+			cancel()
+		}
+		return awserr.New("Throttling", "slow down", nil)
+	})
+	if err != context.Canceled {
+		t.Fatalf("Retry() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNextSleepStaysWithinBaseAndCap(t *testing.T) {
+	base := 100 * time.Millisecond
+	cap := 20 * time.Second
+	prev := base
+
+	for i := 0; i < 100; i++ {
+		next := nextSleep(base, cap, prev)
+		if next < base {
+			t.Fatalf("nextSleep() = %v, want >= base %v", next, base)
+		}
+		if next > cap {
+			t.Fatalf("nextSleep() = %v, want <= cap %v", next, cap)
+		}
+		prev = next
+	}
+}
+
+func TestNextSleepCapsAtCeiling(t *testing.T) {
+	base := 100 * time.Millisecond
+	cap := time.Second
+	next := nextSleep(base, cap, cap*10)
+	if next > cap {
+		t.Errorf("nextSleep() = %v, want <= cap %v", next, cap)
+	}
+}
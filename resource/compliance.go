@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// allUsersURI and authenticatedUsers are the S3 predefined group URIs that extend a grant
+// beyond the bucket owner's own account.
+const (
+	allUsersURI        = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// IsVersioned reports whether bucket versioning is enabled.
+func (b *S3Bucket) IsVersioned() bool {
+	return b.Versioning != nil && aws.StringValue(b.Versioning.Status) == s3.BucketVersioningStatusEnabled
+}
+
+// HasMFADelete reports whether MFA delete is enabled on the bucket, which
+// requires an authenticated MFA device to permanently delete a version or
+// change the bucket's versioning state.
+func (b *S3Bucket) HasMFADelete() bool {
+	return b.Versioning != nil && aws.StringValue(b.Versioning.MFADelete) == s3.MFADeleteStatusEnabled
+}
+
+// HasWORM reports whether Object Lock is enabled with a default retention
+// mode configured, i.e. the bucket actually enforces write-once-read-many
+// immutability rather than merely allowing per-object locks.
+func (b *S3Bucket) HasWORM() bool {
+	if b.ObjectLockConfiguration == nil {
+		return false
+	}
+	if aws.StringValue(b.ObjectLockConfiguration.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+		return false
+	}
+	rule := b.ObjectLockConfiguration.Rule
+	return rule != nil && rule.DefaultRetention != nil && aws.StringValue(rule.DefaultRetention.Mode) != ""
+}
+
+// HasReplicationEnabled reports whether the bucket has at least one enabled
+// replication rule configured. This doesn't by itself confirm the
+// replication is cross-region: a rule's Destination only carries the
+// destination bucket's ARN, not its region, and Same-Region Replication
+// (SRR) sets the same Status=Enabled. Confirming cross-region would require
+// a further GetBucketLocation lookup on the destination bucket.
+func (b *S3Bucket) HasReplicationEnabled() bool {
+	if b.ReplicationConfiguration == nil {
+		return false
+	}
+	for _, rule := range b.ReplicationConfiguration.Rules {
+		if aws.StringValue(rule.Status) == s3.ReplicationRuleStatusEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// ACLGrantsPublicRead reports whether the bucket ACL grants read access
+// (READ or FULL_CONTROL) to AllUsers or AuthenticatedUsers.
+func (b *S3Bucket) ACLGrantsPublicRead() bool {
+	return b.aclGrants(s3.PermissionRead) || b.aclGrants(s3.PermissionFullControl)
+}
+
+// ACLGrantsPublicWrite reports whether the bucket ACL grants write access
+// (WRITE or FULL_CONTROL) to AllUsers or AuthenticatedUsers.
+func (b *S3Bucket) ACLGrantsPublicWrite() bool {
+	return b.aclGrants(s3.PermissionWrite) || b.aclGrants(s3.PermissionFullControl)
+}
+
+func (b *S3Bucket) aclGrants(permission string) bool {
+	if b.ACL == nil {
+		return false
+	}
+	for _, grant := range b.ACL.Grants {
+		if grant.Permission == nil || *grant.Permission != permission {
+			continue
+		}
+		if grant.Grantee == nil {
+			continue
+		}
+		switch aws.StringValue(grant.Grantee.URI) {
+		case allUsersURI, authenticatedUsers:
+			return true
+		}
+	}
+	return false
+}
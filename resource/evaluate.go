@@ -0,0 +1,171 @@
+package resource
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decision is the outcome of evaluating a PolicyRequest against an S3Policy,
+// following the same Allow/Deny semantics IAM exposes to callers.
+type Decision string
+
+const (
+	Allow Decision = "Allow"
+	Deny  Decision = "Deny"
+)
+
+// PolicyRequest describes a single access check: who is asking (Principal),
+// what they want to do (Action, e.g. "s3:GetObject"), on what (Resource ARN),
+// and any request context used by Condition blocks (e.g. "aws:SecureTransport").
+type PolicyRequest struct {
+	Principal string
+	Action    string
+	Resource  string
+	Context   map[string]string
+}
+
+// Evaluate applies the AWS IAM precedence rules to the policy's statements:
+// an explicit Deny always wins, otherwise an explicit Allow wins, otherwise
+// the request is denied by default.
+func (p *S3Policy) Evaluate(req PolicyRequest) Decision {
+	allowed := false
+	for _, statement := range p.Statements {
+		if !statement.matches(req) {
+			continue
+		}
+		switch strings.ToLower(statement.Effect) {
+		case "deny":
+			return Deny
+		case "allow":
+			allowed = true
+		}
+	}
+	if allowed {
+		return Allow
+	}
+	return Deny
+}
+
+func (s *Statement) matches(req PolicyRequest) bool {
+	return s.Principal.matches(req.Principal) &&
+		s.Actions.matches(req.Action) &&
+		matchesARN(s.Resource, req.Resource) &&
+		s.Condition.matches(req.Context)
+}
+
+func (p *Principal) matches(principal string) bool {
+	if p.Wildcard == "*" {
+		return true
+	}
+	for _, values := range p.Map {
+		for _, value := range values {
+			if value == "*" || matchesWildcard(value, principal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a Actions) matches(action string) bool {
+	for _, pattern := range a {
+		if matchesWildcard(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesARN checks a resource ARN against a policy resource pattern such as
+// "arn:aws:s3:::bucket/*", using the same wildcard rules as action matching.
+func matchesARN(pattern, resource string) bool {
+	return matchesWildcard(pattern, resource)
+}
+
+// matchesWildcard matches value against an IAM-style pattern where "*"
+// matches any number of characters and "?" matches exactly one.
+func matchesWildcard(pattern, value string) bool {
+	if pattern == value {
+		return true
+	}
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	re, err := regexp.Compile("^" + quoted + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// matches evaluates every condition operator present against the request
+// context. An operator with no keys is vacuously satisfied; all present
+// operators must be satisfied for the condition block to match.
+func (c *Condition) matches(ctx map[string]string) bool {
+	for key, want := range c.Bool {
+		got, ok := ctx[key]
+		if !ok || !strings.EqualFold(got, want) {
+			return false
+		}
+	}
+	for key, want := range c.Null {
+		_, present := ctx[key]
+		wantNull, err := strconv.ParseBool(want)
+		if err != nil {
+			return false
+		}
+		if present == wantNull {
+			return false
+		}
+	}
+	for key, want := range c.StringEquals {
+		if ctx[key] != want {
+			return false
+		}
+	}
+	for key, want := range c.StringLike {
+		if !matchesWildcard(want, ctx[key]) {
+			return false
+		}
+	}
+	for key, want := range c.IpAddress {
+		if !ipMatchesCIDR(ctx[key], want) {
+			return false
+		}
+	}
+	for key, want := range c.NumericLessThan {
+		if !numericLessThan(ctx[key], want) {
+			return false
+		}
+	}
+	return true
+}
+
+func ipMatchesCIDR(ip, cidr string) bool {
+	if ip == "" {
+		return false
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	return network.Contains(parsed)
+}
+
+func numericLessThan(value, bound string) bool {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	b, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return false
+	}
+	return v < b
+}
@@ -0,0 +1,175 @@
+package resource
+
+import "testing"
+
+func allowAllStatement(resource string) Statement {
+	return Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  resource,
+	}
+}
+
+func TestEvaluateDenyWinsOverAllow(t *testing.T) {
+	policy := &S3Policy{Statements: []Statement{
+		allowAllStatement("arn:aws:s3:::bucket/*"),
+		{
+			Effect:    "Deny",
+			Principal: Principal{Wildcard: "*"},
+			Actions:   Actions{"s3:GetObject"},
+			Resource:  "arn:aws:s3:::bucket/*",
+			Condition: Condition{Bool: map[string]string{"aws:SecureTransport": "false"}},
+		},
+	}}
+
+	got := policy.Evaluate(PolicyRequest{
+		Principal: "*",
+		Action:    "s3:GetObject",
+		Resource:  "arn:aws:s3:::bucket/secret.txt",
+		Context:   map[string]string{"aws:SecureTransport": "false"},
+	})
+	if got != Deny {
+		t.Errorf("Evaluate() = %s, want %s", got, Deny)
+	}
+}
+
+func TestEvaluateDefaultDenyWithNoMatchingStatement(t *testing.T) {
+	policy := &S3Policy{Statements: []Statement{allowAllStatement("arn:aws:s3:::other-bucket/*")}}
+
+	got := policy.Evaluate(PolicyRequest{
+		Principal: "*",
+		Action:    "s3:GetObject",
+		Resource:  "arn:aws:s3:::bucket/secret.txt",
+	})
+	if got != Deny {
+		t.Errorf("Evaluate() = %s, want %s", got, Deny)
+	}
+}
+
+func TestEvaluateAllowWhenStatementMatches(t *testing.T) {
+	policy := &S3Policy{Statements: []Statement{allowAllStatement("arn:aws:s3:::bucket/*")}}
+
+	got := policy.Evaluate(PolicyRequest{
+		Principal: "*",
+		Action:    "s3:GetObject",
+		Resource:  "arn:aws:s3:::bucket/secret.txt",
+	})
+	if got != Allow {
+		t.Errorf("Evaluate() = %s, want %s", got, Allow)
+	}
+}
+
+func TestMatchesWildcard(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"exact match", "s3:GetObject", "s3:GetObject", true},
+		{"star suffix", "s3:Get*", "s3:GetObject", true},
+		{"star prefix and suffix", "s3:*Object", "s3:GetObject", true},
+		{"bare star", "s3:*", "s3:PutObject", true},
+		{"question mark", "s3:GetObjec?", "s3:GetObject", true},
+		{"no match", "s3:Put*", "s3:GetObject", false},
+		{"regex metacharacters are literal", "s3:Get.Object", "s3:GetXObject", false},
+		{"arn prefix", "arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket/key.txt", true},
+		{"arn different bucket", "arn:aws:s3:::bucket/*", "arn:aws:s3:::other/key.txt", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesWildcard(tt.pattern, tt.value); got != tt.want {
+				t.Errorf("matchesWildcard(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionMatchesBool(t *testing.T) {
+	c := Condition{Bool: map[string]string{"aws:SecureTransport": "true"}}
+
+	if !c.matches(map[string]string{"aws:SecureTransport": "true"}) {
+		t.Error("expected match on equal bool values")
+	}
+	if !c.matches(map[string]string{"aws:SecureTransport": "True"}) {
+		t.Error("expected case-insensitive match on bool values")
+	}
+	if c.matches(map[string]string{"aws:SecureTransport": "false"}) {
+		t.Error("expected no match on differing bool values")
+	}
+	if c.matches(map[string]string{}) {
+		t.Error("expected no match when the context key is absent")
+	}
+}
+
+func TestConditionMatchesNull(t *testing.T) {
+	present := Condition{Null: map[string]string{"aws:TokenIssueTime": "false"}}
+	if !present.matches(map[string]string{"aws:TokenIssueTime": "2024-01-01"}) {
+		t.Error("Null=false should match when the key is present")
+	}
+	if present.matches(map[string]string{}) {
+		t.Error("Null=false should not match when the key is absent")
+	}
+
+	absent := Condition{Null: map[string]string{"aws:TokenIssueTime": "true"}}
+	if !absent.matches(map[string]string{}) {
+		t.Error("Null=true should match when the key is absent")
+	}
+	if absent.matches(map[string]string{"aws:TokenIssueTime": "2024-01-01"}) {
+		t.Error("Null=true should not match when the key is present")
+	}
+}
+
+func TestConditionMatchesStringEquals(t *testing.T) {
+	c := Condition{StringEquals: map[string]string{"s3:x-amz-server-side-encryption": "aws:kms"}}
+	if !c.matches(map[string]string{"s3:x-amz-server-side-encryption": "aws:kms"}) {
+		t.Error("expected exact string match")
+	}
+	if c.matches(map[string]string{"s3:x-amz-server-side-encryption": "AES256"}) {
+		t.Error("expected no match on differing string")
+	}
+}
+
+func TestConditionMatchesStringLike(t *testing.T) {
+	c := Condition{StringLike: map[string]string{"aws:userid": "AROA*:*"}}
+	if !c.matches(map[string]string{"aws:userid": "AROAEXAMPLE:session"}) {
+		t.Error("expected wildcard match")
+	}
+	if c.matches(map[string]string{"aws:userid": "AIDAEXAMPLE"}) {
+		t.Error("expected no match for non-matching pattern")
+	}
+}
+
+func TestConditionMatchesIPAddress(t *testing.T) {
+	c := Condition{IpAddress: map[string]string{"aws:SourceIp": "203.0.113.0/24"}}
+	if !c.matches(map[string]string{"aws:SourceIp": "203.0.113.42"}) {
+		t.Error("expected IP inside CIDR to match")
+	}
+	if c.matches(map[string]string{"aws:SourceIp": "198.51.100.1"}) {
+		t.Error("expected IP outside CIDR not to match")
+	}
+	if c.matches(map[string]string{}) {
+		t.Error("expected missing IP context not to match")
+	}
+}
+
+func TestConditionMatchesNumericLessThan(t *testing.T) {
+	c := Condition{NumericLessThan: map[string]string{"s3:max-keys": "10"}}
+	if !c.matches(map[string]string{"s3:max-keys": "5"}) {
+		t.Error("expected 5 < 10 to match")
+	}
+	if c.matches(map[string]string{"s3:max-keys": "10"}) {
+		t.Error("expected 10 < 10 not to match")
+	}
+	if c.matches(map[string]string{"s3:max-keys": "not-a-number"}) {
+		t.Error("expected unparseable value not to match")
+	}
+}
+
+func TestConditionMatchesVacuouslyWhenEmpty(t *testing.T) {
+	var c Condition
+	if !c.matches(map[string]string{"anything": "goes"}) {
+		t.Error("an empty Condition should match any context")
+	}
+}
@@ -0,0 +1,127 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Appliscale/tyr/configuration"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"golang.org/x/sync/errgroup"
+)
+
+// Account identifies a member account to assume RoleARN into before
+// scanning its buckets, mirroring one entry of configuration.Accounts.
+type Account struct {
+	AccountID  string
+	RoleARN    string
+	ExternalID string
+	MFASerial  string
+}
+
+// SessionForAccount builds a session whose credentials come from assuming
+// account.RoleARN from sess, so per-account S3 calls run under the member
+// account's credentials rather than the caller's own.
+func SessionForAccount(sess *session.Session, account Account) (*session.Session, error) {
+	creds := stscreds.NewCredentials(sess, account.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		if account.ExternalID != "" {
+			p.ExternalID = aws.String(account.ExternalID)
+		}
+		if account.MFASerial != "" {
+			p.SerialNumber = aws.String(account.MFASerial)
+		}
+	})
+	return session.NewSession(&aws.Config{Credentials: creds})
+}
+
+// DiscoverOrganizationAccounts lists every active account in the AWS
+// Organization that sess's caller belongs to. It's the auto-discovery mode
+// used when configuration.Accounts is empty. organizations:ListAccounts has
+// no notion of member-account role names, so roleName is used to derive
+// each account's RoleARN as arn:aws:iam::<account-id>:role/<roleName> - the
+// standard shape for a role deployed identically to every account in an
+// Organization (e.g. via a StackSet), such as "OrganizationAccountAccessRole"
+// or a custom audit role.
+func DiscoverOrganizationAccounts(sess *session.Session, roleName string) ([]Account, error) {
+	orgAPI := organizations.New(sess)
+	var accounts []Account
+	err := orgAPI.ListAccountsPages(&organizations.ListAccountsInput{}, func(page *organizations.ListAccountsOutput, lastPage bool) bool {
+		for _, acc := range page.Accounts {
+			if aws.StringValue(acc.Status) != organizations.AccountStatusActive {
+				continue
+			}
+			accountID := aws.StringValue(acc.Id)
+			accounts = append(accounts, Account{
+				AccountID: accountID,
+				RoleARN:   fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, roleName),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// accountsFromConfig resolves the accounts to scan: config.Accounts when
+// it's non-empty, otherwise the explicit accounts parameter (e.g. a
+// hand-assembled []Account or the result of DiscoverOrganizationAccounts).
+func accountsFromConfig(config *configuration.Config, accounts []Account) []Account {
+	if config == nil || len(config.Accounts) == 0 {
+		return accounts
+	}
+	resolved := make([]Account, 0, len(config.Accounts))
+	for _, a := range config.Accounts {
+		resolved = append(resolved, Account{
+			AccountID:  a.AccountID,
+			RoleARN:    a.RoleARN,
+			ExternalID: a.ExternalID,
+		})
+	}
+	return resolved
+}
+
+// ScanOrganization scans every account in parallel - config.Accounts when
+// it's non-empty, otherwise accounts (see accountsFromConfig) - assuming
+// each account's RoleARN from sess, running the existing single-account
+// S3Buckets.LoadFromAWS, then the given rule registry - and returns the
+// combined findings with each Finding's AccountID set.
+func ScanOrganization(sess *session.Session, config *configuration.Config, registry *RuleRegistry, accounts []Account) ([]Finding, error) {
+	accounts = accountsFromConfig(config, accounts)
+
+	var (
+		mu       sync.Mutex
+		findings []Finding
+	)
+
+	g, _ := errgroup.WithContext(context.Background())
+	for _, account := range accounts {
+		account := account
+		g.Go(func() error {
+			accountSess, err := SessionForAccount(sess, account)
+			if err != nil {
+				return fmt.Errorf("[ERROR] account %s: %s", account.AccountID, err.Error())
+			}
+
+			var buckets S3Buckets
+			if err := buckets.LoadFromAWS(accountSess, config); err != nil {
+				return fmt.Errorf("[ERROR] account %s: %s", account.AccountID, err.Error())
+			}
+
+			accountFindings := registry.ScanAccount(buckets, account.AccountID)
+			mu.Lock()
+			findings = append(findings, accountFindings...)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
@@ -0,0 +1,36 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/Appliscale/tyr/configuration"
+)
+
+func TestAccountsFromConfigPrefersConfigAccounts(t *testing.T) {
+	config := &configuration.Config{
+		Accounts: []configuration.Account{
+			{AccountID: "111111111111", RoleARN: "arn:aws:iam::111111111111:role/Audit", ExternalID: "ext-1"},
+		},
+	}
+	explicit := []Account{{AccountID: "222222222222", RoleARN: "arn:aws:iam::222222222222:role/Audit"}}
+
+	got := accountsFromConfig(config, explicit)
+
+	if len(got) != 1 || got[0].AccountID != "111111111111" || got[0].ExternalID != "ext-1" {
+		t.Errorf("accountsFromConfig() = %+v, want config.Accounts converted", got)
+	}
+}
+
+func TestAccountsFromConfigFallsBackToExplicitAccounts(t *testing.T) {
+	explicit := []Account{{AccountID: "222222222222", RoleARN: "arn:aws:iam::222222222222:role/Audit"}}
+
+	got := accountsFromConfig(&configuration.Config{}, explicit)
+	if len(got) != 1 || got[0].AccountID != "222222222222" {
+		t.Errorf("accountsFromConfig() = %+v, want the explicit accounts unchanged", got)
+	}
+
+	got = accountsFromConfig(nil, explicit)
+	if len(got) != 1 || got[0].AccountID != "222222222222" {
+		t.Errorf("accountsFromConfig(nil, ...) = %+v, want the explicit accounts unchanged", got)
+	}
+}
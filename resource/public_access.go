@@ -0,0 +1,146 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// PublicAccessVerdict classifies how broadly a bucket's policy exposes it.
+type PublicAccessVerdict string
+
+const (
+	Public            PublicAccessVerdict = "Public"
+	ConditionalPublic PublicAccessVerdict = "ConditionalPublic"
+	CrossAccount      PublicAccessVerdict = "CrossAccount"
+	Private           PublicAccessVerdict = "Private"
+)
+
+// PublicAccessReport is the result of scanning a bucket's policy for
+// statements that grant access beyond the bucket's own account.
+type PublicAccessReport struct {
+	Verdict             PublicAccessVerdict
+	OffendingStatements []int
+	Reasons             []string
+}
+
+// sensitiveActions are the S3 actions whose exposure to "*" or another
+// account is considered a public-access risk worth flagging.
+var sensitiveActions = []string{
+	"s3:GetObject",
+	"s3:ListBucket",
+	"s3:PutObject",
+	"s3:*",
+}
+
+// ClassifyPublicAccess inspects the bucket's policy statements and its
+// bucket-level Public Access Block configuration to determine whether the
+// bucket is reachable outside its own account.
+func (b *S3Bucket) ClassifyPublicAccess() PublicAccessReport {
+	report := PublicAccessReport{Verdict: Private}
+
+	if b.S3Policy == nil {
+		return report
+	}
+
+	bucketARN := fmt.Sprintf("arn:aws:s3:::%s", aws.StringValue(b.Name))
+
+	for i, statement := range b.S3Policy.Statements {
+		if !isAllowEffect(statement.Effect) {
+			continue
+		}
+		if !coversAnySensitiveAction(statement.Actions) {
+			continue
+		}
+		if !resourceCoversBucket(statement.Resource, bucketARN) {
+			continue
+		}
+
+		switch {
+		case statement.Principal.Wildcard == "*" || contains(statement.Principal.Map["AWS"], "*"):
+			if statement.Condition.isEmpty() {
+				report.Verdict = promote(report.Verdict, Public)
+				report.Reasons = append(report.Reasons, fmt.Sprintf("statement %d grants %s to Principal \"*\" with no narrowing condition", i, statement.Resource))
+			} else {
+				report.Verdict = promote(report.Verdict, ConditionalPublic)
+				report.Reasons = append(report.Reasons, fmt.Sprintf("statement %d grants %s to Principal \"*\" narrowed by a condition", i, statement.Resource))
+			}
+			report.OffendingStatements = append(report.OffendingStatements, i)
+		case len(statement.Principal.Map["AWS"]) > 0:
+			report.Verdict = promote(report.Verdict, CrossAccount)
+			report.Reasons = append(report.Reasons, fmt.Sprintf("statement %d grants %s to another account", i, statement.Resource))
+			report.OffendingStatements = append(report.OffendingStatements, i)
+		}
+	}
+
+	if b.PublicAccessBlockConfiguration != nil && aws.BoolValue(b.PublicAccessBlockConfiguration.BlockPublicPolicy) {
+		report.Verdict = Private
+	}
+
+	return report
+}
+
+// resourceCoversBucket reports whether a policy Resource ARN - which may
+// carry IAM wildcards - grants access to the bucket itself or to any object
+// in it. This covers the whole-bucket ARN, the conventional "bucket/*"
+// suffix, and prefix-scoped grants such as "bucket/public/*" (the common
+// static-asset pattern), which are real objects under the bucket even though
+// they don't match either literal ARN exactly.
+func resourceCoversBucket(resource, bucketARN string) bool {
+	if matchesWildcard(resource, bucketARN) {
+		return true
+	}
+	objectsPrefix := bucketARN + "/"
+	if strings.HasPrefix(resource, objectsPrefix) {
+		return true
+	}
+	// The pattern may wildcard the bucket name and match only object ARNs,
+	// e.g. "arn:aws:s3:::buck*/*" - check it against a representative
+	// object key rather than just the bucket ARN.
+	return matchesWildcard(resource, objectsPrefix+"object")
+}
+
+// coversAnySensitiveAction reports whether the statement's action list
+// matches at least one of the actions we consider sensitive.
+func coversAnySensitiveAction(actions Actions) bool {
+	for _, sensitive := range sensitiveActions {
+		if actions.matches(sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowEffect(effect string) bool {
+	return effect == "Allow"
+}
+
+func (c *Condition) isEmpty() bool {
+	return len(c.Bool) == 0 && len(c.Null) == 0 && len(c.StringEquals) == 0 &&
+		len(c.StringLike) == 0 && len(c.IpAddress) == 0 && len(c.NumericLessThan) == 0
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// promote returns the more severe of the two verdicts, ranking
+// Public > ConditionalPublic > CrossAccount > Private.
+func promote(current, candidate PublicAccessVerdict) PublicAccessVerdict {
+	rank := map[PublicAccessVerdict]int{
+		Public:            3,
+		ConditionalPublic: 2,
+		CrossAccount:      1,
+		Private:           0,
+	}
+	if rank[candidate] > rank[current] {
+		return candidate
+	}
+	return current
+}
@@ -0,0 +1,163 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func bucketWithPolicy(name string, statements ...Statement) *S3Bucket {
+	return &S3Bucket{
+		Bucket:   &s3.Bucket{Name: aws.String(name)},
+		S3Policy: &S3Policy{Statements: statements},
+	}
+}
+
+func TestClassifyPublicAccessNoPolicy(t *testing.T) {
+	b := &S3Bucket{Bucket: &s3.Bucket{Name: aws.String("bucket")}}
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Private {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, Private)
+	}
+}
+
+func TestClassifyPublicAccessPublicWildcardPrincipal(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+	})
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Public {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, Public)
+	}
+	if len(report.OffendingStatements) != 1 || report.OffendingStatements[0] != 0 {
+		t.Errorf("OffendingStatements = %v, want [0]", report.OffendingStatements)
+	}
+}
+
+func TestClassifyPublicAccessPublicWithPrefixScopedResource(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/public/*",
+	})
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Public {
+		t.Errorf("Verdict = %s, want %s (prefix-scoped resources grant public access to real objects)", report.Verdict, Public)
+	}
+}
+
+func TestClassifyPublicAccessConditionalPublic(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+		Condition: Condition{IpAddress: map[string]string{"aws:SourceIp": "203.0.113.0/24"}},
+	})
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != ConditionalPublic {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, ConditionalPublic)
+	}
+}
+
+func TestClassifyPublicAccessCrossAccount(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Map: map[string][]string{"AWS": {"arn:aws:iam::999999999999:root"}}},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+	})
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != CrossAccount {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, CrossAccount)
+	}
+}
+
+func TestClassifyPublicAccessPrivateWhenActionNotSensitive(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetBucketLocation"},
+		Resource:  "arn:aws:s3:::bucket/*",
+	})
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Private {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, Private)
+	}
+}
+
+func TestClassifyPublicAccessBlockPublicPolicyOverridesToPrivate(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+	})
+	b.PublicAccessBlockConfiguration = &s3.PublicAccessBlockConfiguration{
+		BlockPublicPolicy: aws.Bool(true),
+	}
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Private {
+		t.Errorf("Verdict = %s, want %s", report.Verdict, Private)
+	}
+}
+
+func TestResourceCoversBucket(t *testing.T) {
+	const bucketARN = "arn:aws:s3:::bucket"
+	tests := []struct {
+		name     string
+		resource string
+		want     bool
+	}{
+		{"whole bucket ARN", "arn:aws:s3:::bucket", true},
+		{"conventional objects wildcard", "arn:aws:s3:::bucket/*", true},
+		{"prefix-scoped static assets", "arn:aws:s3:::bucket/public/*", true},
+		{"single object", "arn:aws:s3:::bucket/key.txt", true},
+		{"wildcarded bucket name segment", "arn:aws:s3:::bucket*", true},
+		{"different bucket", "arn:aws:s3:::other-bucket/*", false},
+		{"different bucket with shared prefix", "arn:aws:s3:::bucket-other/*", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceCoversBucket(tt.resource, bucketARN); got != tt.want {
+				t.Errorf("resourceCoversBucket(%q, %q) = %v, want %v", tt.resource, bucketARN, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyPublicAccessPromotesToMostSevereVerdict(t *testing.T) {
+	b := bucketWithPolicy("bucket",
+		Statement{
+			Effect:    "Allow",
+			Principal: Principal{Map: map[string][]string{"AWS": {"arn:aws:iam::999999999999:root"}}},
+			Actions:   Actions{"s3:GetObject"},
+			Resource:  "arn:aws:s3:::bucket/*",
+		},
+		Statement{
+			Effect:    "Allow",
+			Principal: Principal{Wildcard: "*"},
+			Actions:   Actions{"s3:GetObject"},
+			Resource:  "arn:aws:s3:::bucket/*",
+		},
+	)
+
+	report := b.ClassifyPublicAccess()
+	if report.Verdict != Public {
+		t.Errorf("Verdict = %s, want %s (the more severe of CrossAccount and Public)", report.Verdict, Public)
+	}
+	if len(report.OffendingStatements) != 2 {
+		t.Errorf("OffendingStatements = %v, want both statements flagged", report.OffendingStatements)
+	}
+}
@@ -0,0 +1,160 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Reporter renders a set of findings into a particular output format.
+type Reporter interface {
+	Report(findings []Finding) ([]byte, error)
+}
+
+// TextReporter renders findings as plain, human-readable lines.
+type TextReporter struct{}
+
+func (TextReporter) Report(findings []Finding) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, f := range findings {
+		if f.AccountID != "" {
+			fmt.Fprintf(&buf, "[%s] %s/%s: %s (%s)\n", f.Severity, f.AccountID, f.Bucket, f.Message, f.RuleID)
+		} else {
+			fmt.Fprintf(&buf, "[%s] %s: %s (%s)\n", f.Severity, f.Bucket, f.Message, f.RuleID)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONReporter renders findings as a JSON array.
+type JSONReporter struct{}
+
+func (JSONReporter) Report(findings []Finding) ([]byte, error) {
+	return json.MarshalIndent(findings, "", "  ")
+}
+
+// SARIFReporter renders findings as a SARIF 2.1.0 log, so they can be
+// consumed by GitHub code scanning or other security dashboards that
+// understand the format.
+type SARIFReporter struct {
+	ToolName string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+func (r SARIFReporter) Report(findings []Finding) ([]byte, error) {
+	toolName := r.ToolName
+	if toolName == "" {
+		toolName = "cloud-security-audit"
+	}
+
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+	for _, f := range findings {
+		if !ruleIDs[f.RuleID] {
+			ruleIDs[f.RuleID] = true
+			rules = append(rules, sarifRule{ID: f.RuleID})
+		}
+		logicalLocations := []sarifLogicalLocation{{Name: f.Bucket, Kind: "resource"}}
+		if f.AccountID != "" {
+			logicalLocations = append(logicalLocations, sarifLogicalLocation{Name: f.AccountID, Kind: "module"})
+		}
+		results = append(results, sarifResult{
+			RuleID:  f.RuleID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: sarifArtifactURI(f)},
+				},
+				LogicalLocations: logicalLocations,
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: toolName, Rules: rules}},
+			Results: results,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// sarifArtifactURI synthesizes a stable, per-bucket artifact path so GitHub
+// code scanning has a physicalLocation to anchor the result to - buckets
+// aren't files, so this doesn't point at a real path, just a place to group
+// findings by account/bucket the way a real file would.
+func sarifArtifactURI(f Finding) string {
+	if f.AccountID != "" {
+		return fmt.Sprintf("buckets/%s/%s.json", f.AccountID, f.Bucket)
+	}
+	return fmt.Sprintf("buckets/%s.json", f.Bucket)
+}
+
+// sarifLevel maps our Severity scale onto the SARIF result.level enum
+// (none, note, warning, error).
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleFindings() []Finding {
+	return []Finding{
+		{RuleID: "S3-NO-SSE", Severity: SeverityHigh, Bucket: "bucket-a", Message: "bucket has no server-side encryption configuration"},
+		{RuleID: "S3-POLICY-PUBLIC", Severity: SeverityCritical, AccountID: "111111111111", Bucket: "bucket-b", Message: "bucket policy grants public access with no narrowing condition"},
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	out, err := TextReporter{}.Report(sampleFindings())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), out)
+	}
+	if !strings.Contains(lines[0], "bucket-a") || strings.Contains(lines[0], "/") {
+		t.Errorf("line without AccountID = %q, want bucket name with no account prefix", lines[0])
+	}
+	if !strings.Contains(lines[1], "111111111111/bucket-b") {
+		t.Errorf("line with AccountID = %q, want %q prefixed with the account", lines[1], "111111111111/bucket-b")
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	out, err := JSONReporter{}.Report(sampleFindings())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	var findings []Finding
+	if err := json.Unmarshal(out, &findings); err != nil {
+		t.Fatalf("output isn't valid JSON: %v", err)
+	}
+	if len(findings) != 2 || findings[0].RuleID != "S3-NO-SSE" {
+		t.Errorf("findings = %+v, want the two sample findings round-tripped", findings)
+	}
+}
+
+func TestSARIFReporterShape(t *testing.T) {
+	out, err := SARIFReporter{}.Report(sampleFindings())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out, &log); err != nil {
+		t.Fatalf("output isn't valid SARIF JSON: %v", err)
+	}
+	if log.Version != "2.1.0" {
+		t.Errorf("Version = %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "cloud-security-audit" {
+		t.Errorf("Driver.Name = %q, want default tool name", run.Tool.Driver.Name)
+	}
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Errorf("got %d driver rules, want one per distinct RuleID", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(run.Results))
+	}
+
+	result := run.Results[0]
+	if result.RuleID != "S3-NO-SSE" || result.Level != "error" {
+		t.Errorf("first result = %+v, want RuleID S3-NO-SSE at level error", result)
+	}
+	if len(result.Locations) != 1 {
+		t.Fatalf("got %d locations, want 1", len(result.Locations))
+	}
+	loc := result.Locations[0]
+	if loc.PhysicalLocation.ArtifactLocation.URI == "" {
+		t.Error("PhysicalLocation.ArtifactLocation.URI is empty, want a synthesized per-bucket path so GitHub code scanning can anchor the result")
+	}
+	if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].Name != "bucket-a" {
+		t.Errorf("LogicalLocations = %+v, want the bucket name", loc.LogicalLocations)
+	}
+
+	withAccount := run.Results[1]
+	if len(withAccount.Locations[0].LogicalLocations) != 2 {
+		t.Errorf("got %d logical locations for a finding with AccountID, want 2 (bucket + account)", len(withAccount.Locations[0].LogicalLocations))
+	}
+	if !strings.Contains(withAccount.Locations[0].PhysicalLocation.ArtifactLocation.URI, "111111111111") {
+		t.Errorf("ArtifactLocation.URI = %q, want it to include the AccountID", withAccount.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+}
+
+func TestSARIFLevelMapping(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		want     string
+	}{
+		{SeverityCritical, "error"},
+		{SeverityHigh, "error"},
+		{SeverityMedium, "warning"},
+		{SeverityLow, "note"},
+	}
+	for _, tt := range tests {
+		if got := sarifLevel(tt.severity); got != tt.want {
+			t.Errorf("sarifLevel(%s) = %q, want %q", tt.severity, got, tt.want)
+		}
+	}
+}
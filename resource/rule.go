@@ -0,0 +1,82 @@
+package resource
+
+// Severity ranks how urgently a Finding should be addressed.
+type Severity string
+
+const (
+	SeverityLow      Severity = "LOW"
+	SeverityMedium   Severity = "MEDIUM"
+	SeverityHigh     Severity = "HIGH"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// Finding is a single rule violation surfaced against one bucket.
+// AccountID is left empty for a single-account scan and set by
+// ScanOrganization so the reporter can group output per account.
+type Finding struct {
+	RuleID    string
+	Severity  Severity
+	AccountID string `json:",omitempty"`
+	Bucket    string
+	Message   string
+}
+
+// Rule is a single, independently testable audit check. Check receives a
+// fully populated bucket (i.e. after S3Buckets.LoadFromAWS) and returns zero
+// or more findings against it.
+type Rule interface {
+	ID() string
+	Severity() Severity
+	Check(b *S3Bucket) []Finding
+}
+
+// RuleRegistry holds the set of rules a scan runs, plus any rule IDs the
+// caller has chosen to suppress.
+type RuleRegistry struct {
+	rules      []Rule
+	suppressed map[string]bool
+}
+
+// NewRuleRegistry returns a registry pre-populated with the built-in rules.
+func NewRuleRegistry() *RuleRegistry {
+	r := &RuleRegistry{suppressed: make(map[string]bool)}
+	r.Register(BuiltinRules()...)
+	return r
+}
+
+// Register adds rules to the registry. Custom rules implementing the Rule
+// interface can be registered alongside, or instead of, the built-ins.
+func (r *RuleRegistry) Register(rules ...Rule) {
+	r.rules = append(r.rules, rules...)
+}
+
+// Suppress disables a rule by ID; suppressed rules are skipped by Scan.
+func (r *RuleRegistry) Suppress(ruleIDs ...string) {
+	for _, id := range ruleIDs {
+		r.suppressed[id] = true
+	}
+}
+
+// Scan runs every enabled rule against every bucket and returns the combined
+// findings.
+func (r *RuleRegistry) Scan(buckets S3Buckets) []Finding {
+	return r.ScanAccount(buckets, "")
+}
+
+// ScanAccount is like Scan but stamps every finding with accountID, for use
+// when scanning more than one AWS account in a single run.
+func (r *RuleRegistry) ScanAccount(buckets S3Buckets, accountID string) []Finding {
+	var findings []Finding
+	for _, rule := range r.rules {
+		if r.suppressed[rule.ID()] {
+			continue
+		}
+		for _, bucket := range buckets {
+			for _, finding := range rule.Check(bucket) {
+				finding.AccountID = accountID
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
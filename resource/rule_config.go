@@ -0,0 +1,49 @@
+package resource
+
+import (
+	"io/ioutil"
+
+	"github.com/Appliscale/tyr/configuration"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RulesConfig is the YAML shape for suppressing built-in rules, e.g.:
+//
+//	disabled:
+//	  - S3-NO-OBJECT-LOCK
+//	  - S3-UNEXPECTED-REGION
+type RulesConfig struct {
+	Disabled []string `yaml:"disabled"`
+}
+
+// LoadRulesConfig reads a RulesConfig from the YAML file at path. Use
+// LoadRulesConfigFromConfig to read the path from configuration.Config's
+// RulesConfigPath instead; ApplyRulesConfig then suppresses the result onto
+// the registry the same way in both cases.
+func LoadRulesConfig(path string) (RulesConfig, error) {
+	var cfg RulesConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// LoadRulesConfigFromConfig loads the RulesConfig at config.RulesConfigPath.
+// A nil config or an empty path yields an empty RulesConfig and no error.
+func LoadRulesConfigFromConfig(config *configuration.Config) (RulesConfig, error) {
+	if config == nil || config.RulesConfigPath == "" {
+		return RulesConfig{}, nil
+	}
+	return LoadRulesConfig(config.RulesConfigPath)
+}
+
+// ApplyRulesConfig suppresses every rule ID listed in cfg.Disabled on the
+// registry.
+func ApplyRulesConfig(registry *RuleRegistry, cfg RulesConfig) {
+	registry.Suppress(cfg.Disabled...)
+}
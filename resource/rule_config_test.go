@@ -0,0 +1,76 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Appliscale/tyr/configuration"
+)
+
+func TestLoadRulesConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	if err := os.WriteFile(path, []byte("disabled:\n  - S3-NO-OBJECT-LOCK\n  - S3-UNEXPECTED-REGION\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := LoadRulesConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRulesConfig() error = %v", err)
+	}
+	want := []string{"S3-NO-OBJECT-LOCK", "S3-UNEXPECTED-REGION"}
+	if len(cfg.Disabled) != len(want) || cfg.Disabled[0] != want[0] || cfg.Disabled[1] != want[1] {
+		t.Errorf("Disabled = %v, want %v", cfg.Disabled, want)
+	}
+}
+
+func TestLoadRulesConfigMissingFile(t *testing.T) {
+	if _, err := LoadRulesConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadRulesConfig() error = nil, want an error for a missing file")
+	}
+}
+
+func TestLoadRulesConfigFromConfigEmptyPath(t *testing.T) {
+	cfg, err := LoadRulesConfigFromConfig(&configuration.Config{})
+	if err != nil {
+		t.Fatalf("LoadRulesConfigFromConfig() error = %v", err)
+	}
+	if len(cfg.Disabled) != 0 {
+		t.Errorf("Disabled = %v, want empty when RulesConfigPath is unset", cfg.Disabled)
+	}
+
+	if _, err := LoadRulesConfigFromConfig(nil); err != nil {
+		t.Errorf("LoadRulesConfigFromConfig(nil) error = %v, want nil", err)
+	}
+}
+
+func TestLoadRulesConfigFromConfigReadsPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	if err := os.WriteFile(path, []byte("disabled:\n  - S3-NO-VERSIONING\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := LoadRulesConfigFromConfig(&configuration.Config{RulesConfigPath: path})
+	if err != nil {
+		t.Fatalf("LoadRulesConfigFromConfig() error = %v", err)
+	}
+	if len(cfg.Disabled) != 1 || cfg.Disabled[0] != "S3-NO-VERSIONING" {
+		t.Errorf("Disabled = %v, want [S3-NO-VERSIONING]", cfg.Disabled)
+	}
+}
+
+func TestApplyRulesConfigSuppressesDisabledRules(t *testing.T) {
+	registry := NewRuleRegistry()
+	ApplyRulesConfig(registry, RulesConfig{Disabled: []string{"S3-NO-SSE", "S3-NO-VERSIONING"}})
+
+	findings := registry.Scan(S3Buckets{newBucket("bucket")})
+	ids := findingIDs(findings)
+	if ids["S3-NO-SSE"] || ids["S3-NO-VERSIONING"] {
+		t.Errorf("findings = %v, want S3-NO-SSE and S3-NO-VERSIONING suppressed", findings)
+	}
+	if !ids["S3-NO-ACCESS-LOGGING"] {
+		t.Errorf("findings = %v, want non-suppressed rules to still fire", findings)
+	}
+}
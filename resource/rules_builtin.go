@@ -0,0 +1,195 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// ruleHit is one violation reported by a funcRule's check function, before
+// it's stamped with the rule's ID/bucket and turned into a Finding.
+// Severity is optional; an empty value falls back to the rule's own.
+type ruleHit struct {
+	message  string
+	severity Severity
+}
+
+// hit reports a violation at the rule's own severity.
+func hit(message string) ruleHit {
+	return ruleHit{message: message}
+}
+
+// hitWithSeverity reports a violation at a severity other than the rule's
+// default, e.g. downgrading a conditional finding.
+func hitWithSeverity(message string, severity Severity) ruleHit {
+	return ruleHit{message: message, severity: severity}
+}
+
+// funcRule adapts a plain check function to the Rule interface so built-in
+// rules don't each need their own named type. check only inspects the
+// bucket and reports hits; funcRule.Check stamps RuleID/Severity/Bucket onto
+// each one, so check never needs to refer back to its own rule variable.
+type funcRule struct {
+	id       string
+	severity Severity
+	check    func(*S3Bucket) []ruleHit
+}
+
+func (f *funcRule) ID() string         { return f.id }
+func (f *funcRule) Severity() Severity { return f.severity }
+
+func (f *funcRule) Check(b *S3Bucket) []Finding {
+	hits := f.check(b)
+	if len(hits) == 0 {
+		return nil
+	}
+	findings := make([]Finding, 0, len(hits))
+	for _, h := range hits {
+		severity := f.severity
+		if h.severity != "" {
+			severity = h.severity
+		}
+		findings = append(findings, Finding{
+			RuleID:   f.id,
+			Severity: severity,
+			Bucket:   aws.StringValue(b.Name),
+			Message:  h.message,
+		})
+	}
+	return findings
+}
+
+// BuiltinRules returns the rules shipped out of the box, covering the same
+// checks that used to be scattered across ad-hoc "did we find SSE?" style
+// code. NewRuleRegistry registers these by default.
+func BuiltinRules() []Rule {
+	return []Rule{
+		ruleNoSSE,
+		ruleSSENotKMS,
+		ruleNoAccessLogging,
+		rulePublicPolicy,
+		ruleNoTLSOnlyCondition,
+		ruleNoVersioning,
+		ruleNoObjectLock,
+	}
+}
+
+var ruleNoSSE = &funcRule{
+	id:       "S3-NO-SSE",
+	severity: SeverityHigh,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.ServerSideEncryptionConfiguration != nil {
+			return nil
+		}
+		return []ruleHit{hit("bucket has no server-side encryption configuration")}
+	},
+}
+
+var ruleSSENotKMS = &funcRule{
+	id:       "S3-SSE-NOT-KMS",
+	severity: SeverityMedium,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.ServerSideEncryptionConfiguration == nil {
+			return nil
+		}
+		for _, rule := range b.ServerSideEncryptionConfiguration.Rules {
+			apply := rule.ApplyServerSideEncryptionByDefault
+			if apply == nil || aws.StringValue(apply.SSEAlgorithm) == "aws:kms" {
+				continue
+			}
+			return []ruleHit{hit(fmt.Sprintf("default encryption uses %s, not aws:kms", aws.StringValue(apply.SSEAlgorithm)))}
+		}
+		return nil
+	},
+}
+
+var ruleNoAccessLogging = &funcRule{
+	id:       "S3-NO-ACCESS-LOGGING",
+	severity: SeverityLow,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.LoggingEnabled != nil {
+			return nil
+		}
+		return []ruleHit{hit("bucket access logging is not enabled")}
+	},
+}
+
+var rulePublicPolicy = &funcRule{
+	id:       "S3-POLICY-PUBLIC",
+	severity: SeverityCritical,
+	check: func(b *S3Bucket) []ruleHit {
+		report := b.ClassifyPublicAccess()
+		switch report.Verdict {
+		case Public:
+			return []ruleHit{hit("bucket policy grants public access with no narrowing condition")}
+		case ConditionalPublic:
+			return []ruleHit{hitWithSeverity("bucket policy grants public access narrowed only by a condition", SeverityMedium)}
+		default:
+			return nil
+		}
+	},
+}
+
+var ruleNoTLSOnlyCondition = &funcRule{
+	id:       "S3-NO-TLS-ONLY",
+	severity: SeverityMedium,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.S3Policy == nil {
+			return []ruleHit{hit("bucket has no policy enforcing aws:SecureTransport")}
+		}
+		for _, statement := range b.S3Policy.Statements {
+			if statement.Effect != "Deny" {
+				continue
+			}
+			if value, ok := statement.Condition.Bool["aws:SecureTransport"]; ok && strings.EqualFold(value, "false") {
+				return nil
+			}
+		}
+		return []ruleHit{hit("bucket policy does not deny non-TLS requests via aws:SecureTransport")}
+	},
+}
+
+var ruleNoVersioning = &funcRule{
+	id:       "S3-NO-VERSIONING",
+	severity: SeverityLow,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.IsVersioned() {
+			return nil
+		}
+		return []ruleHit{hit("bucket versioning is not enabled")}
+	},
+}
+
+var ruleNoObjectLock = &funcRule{
+	id:       "S3-NO-OBJECT-LOCK",
+	severity: SeverityLow,
+	check: func(b *S3Bucket) []ruleHit {
+		if b.HasWORM() {
+			return nil
+		}
+		return []ruleHit{hit("bucket has no Object Lock retention configured")}
+	},
+}
+
+// NewUnexpectedRegionRule builds a rule flagging any bucket whose region is
+// not in allowed. It isn't part of BuiltinRules because the allowed set is
+// deployment-specific; register it explicitly, e.g.
+// registry.Register(NewUnexpectedRegionRule([]string{"us-east-1", "eu-west-1"})).
+func NewUnexpectedRegionRule(allowed []string) Rule {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, region := range allowed {
+		allowedSet[region] = true
+	}
+	return &funcRule{
+		id:       "S3-UNEXPECTED-REGION",
+		severity: SeverityMedium,
+		check: func(b *S3Bucket) []ruleHit {
+			region := aws.StringValue(b.Region)
+			if region == "" || allowedSet[region] {
+				return nil
+			}
+			return []ruleHit{hit(fmt.Sprintf("bucket is in unexpected region %q", region))}
+		},
+	}
+}
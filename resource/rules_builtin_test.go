@@ -0,0 +1,194 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func newBucket(name string) *S3Bucket {
+	return &S3Bucket{Bucket: &s3.Bucket{Name: aws.String(name)}}
+}
+
+func findingIDs(findings []Finding) map[string]bool {
+	ids := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		ids[f.RuleID] = true
+	}
+	return ids
+}
+
+func TestRuleNoSSE(t *testing.T) {
+	b := newBucket("bucket")
+	if got := ruleNoSSE.Check(b); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding for a bucket with no SSE config", got)
+	}
+
+	b.ServerSideEncryptionConfiguration = &s3.ServerSideEncryptionConfiguration{}
+	if got := ruleNoSSE.Check(b); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding once SSE is configured", got)
+	}
+}
+
+func TestRuleSSENotKMS(t *testing.T) {
+	b := newBucket("bucket")
+	b.ServerSideEncryptionConfiguration = &s3.ServerSideEncryptionConfiguration{
+		Rules: []*s3.ServerSideEncryptionRule{{
+			ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+				SSEAlgorithm: aws.String("AES256"),
+			},
+		}},
+	}
+	if got := ruleSSENotKMS.Check(b); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding for AES256 default encryption", got)
+	}
+
+	b.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = aws.String("aws:kms")
+	if got := ruleSSENotKMS.Check(b); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding for aws:kms default encryption", got)
+	}
+}
+
+func TestRuleNoAccessLogging(t *testing.T) {
+	b := newBucket("bucket")
+	if got := ruleNoAccessLogging.Check(b); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding with no logging configured", got)
+	}
+
+	b.LoggingEnabled = &s3.LoggingEnabled{}
+	if got := ruleNoAccessLogging.Check(b); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding once logging is enabled", got)
+	}
+}
+
+func TestRulePublicPolicy(t *testing.T) {
+	b := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+	})
+
+	got := rulePublicPolicy.Check(b)
+	if len(got) != 1 || got[0].Severity != SeverityCritical {
+		t.Fatalf("Check() = %v, want one CRITICAL finding for unconditional public access", got)
+	}
+
+	conditional := bucketWithPolicy("bucket", Statement{
+		Effect:    "Allow",
+		Principal: Principal{Wildcard: "*"},
+		Actions:   Actions{"s3:GetObject"},
+		Resource:  "arn:aws:s3:::bucket/*",
+		Condition: Condition{IpAddress: map[string]string{"aws:SourceIp": "203.0.113.0/24"}},
+	})
+	got = rulePublicPolicy.Check(conditional)
+	if len(got) != 1 || got[0].Severity != SeverityMedium {
+		t.Fatalf("Check() = %v, want one MEDIUM finding for conditionally public access", got)
+	}
+}
+
+func TestRuleNoTLSOnlyCondition(t *testing.T) {
+	noPolicy := newBucket("bucket")
+	if got := ruleNoTLSOnlyCondition.Check(noPolicy); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding when there's no policy at all", got)
+	}
+
+	noDeny := bucketWithPolicy("bucket", Statement{
+		Effect:   "Allow",
+		Resource: "arn:aws:s3:::bucket/*",
+	})
+	if got := ruleNoTLSOnlyCondition.Check(noDeny); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding when no statement denies non-TLS access", got)
+	}
+
+	denyLowercase := bucketWithPolicy("bucket", Statement{
+		Effect:    "Deny",
+		Resource:  "arn:aws:s3:::bucket/*",
+		Condition: Condition{Bool: map[string]string{"aws:SecureTransport": "false"}},
+	})
+	if got := ruleNoTLSOnlyCondition.Check(denyLowercase); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding when the statement denies aws:SecureTransport=false", got)
+	}
+
+	// A value of "False" (capital F) is semantically identical to "false"
+	// and is what the evaluator itself treats case-insensitively - the
+	// rule must agree, or a real TLS-enforcing policy is flagged as if it
+	// weren't enforcing TLS at all.
+	denyMixedCase := bucketWithPolicy("bucket", Statement{
+		Effect:    "Deny",
+		Resource:  "arn:aws:s3:::bucket/*",
+		Condition: Condition{Bool: map[string]string{"aws:SecureTransport": "False"}},
+	})
+	if got := ruleNoTLSOnlyCondition.Check(denyMixedCase); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding for a SecureTransport=\"False\" deny statement", got)
+	}
+}
+
+func TestRuleNoVersioning(t *testing.T) {
+	b := newBucket("bucket")
+	if got := ruleNoVersioning.Check(b); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding when versioning isn't enabled", got)
+	}
+
+	b.Versioning = &s3.GetBucketVersioningOutput{Status: aws.String(s3.BucketVersioningStatusEnabled)}
+	if got := ruleNoVersioning.Check(b); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding once versioning is enabled", got)
+	}
+}
+
+func TestRuleNoObjectLock(t *testing.T) {
+	b := newBucket("bucket")
+	if got := ruleNoObjectLock.Check(b); len(got) != 1 {
+		t.Fatalf("Check() = %v, want one finding with no Object Lock configured", got)
+	}
+
+	b.ObjectLockConfiguration = &s3.ObjectLockConfiguration{
+		ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled),
+		Rule: &s3.ObjectLockRule{
+			DefaultRetention: &s3.DefaultRetention{Mode: aws.String(s3.ObjectLockRetentionModeCompliance)},
+		},
+	}
+	if got := ruleNoObjectLock.Check(b); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding once WORM retention is configured", got)
+	}
+}
+
+func TestNewUnexpectedRegionRule(t *testing.T) {
+	rule := NewUnexpectedRegionRule([]string{"us-east-1"})
+
+	allowed := newBucket("bucket")
+	allowed.Region = aws.String("us-east-1")
+	if got := rule.Check(allowed); len(got) != 0 {
+		t.Errorf("Check() = %v, want no finding for an allowed region", got)
+	}
+
+	unexpected := newBucket("bucket")
+	unexpected.Region = aws.String("eu-west-1")
+	if got := rule.Check(unexpected); len(got) != 1 {
+		t.Errorf("Check() = %v, want one finding for a region outside the allowed set", got)
+	}
+}
+
+func TestBuiltinRulesCoverDistinctIDs(t *testing.T) {
+	rules := BuiltinRules()
+	ids := make(map[string]bool)
+	for _, r := range rules {
+		if ids[r.ID()] {
+			t.Errorf("duplicate rule ID %q in BuiltinRules()", r.ID())
+		}
+		ids[r.ID()] = true
+	}
+}
+
+func TestRuleRegistryScanSkipsSuppressedRules(t *testing.T) {
+	registry := NewRuleRegistry()
+	registry.Suppress("S3-NO-SSE")
+
+	buckets := S3Buckets{newBucket("bucket")}
+	findings := registry.Scan(buckets)
+
+	if ids := findingIDs(findings); ids["S3-NO-SSE"] {
+		t.Errorf("findings = %v, want S3-NO-SSE suppressed", findings)
+	}
+}
@@ -1,16 +1,18 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"sync"
 
 	"github.com/Appliscale/tyr/configuration"
+	"github.com/Appliscale/tyr/pool"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"golang.org/x/sync/errgroup"
 )
 
 type S3Bucket struct {
@@ -19,6 +21,12 @@ type S3Bucket struct {
 	Region   *string
 	*s3.ServerSideEncryptionConfiguration
 	*s3.LoggingEnabled
+	*s3.PublicAccessBlockConfiguration
+	*s3.ObjectLockConfiguration
+	*s3.ReplicationConfiguration
+	ACL        *s3.GetBucketAclOutput
+	Versioning *s3.GetBucketVersioningOutput
+	Tags       []*s3.Tag
 }
 
 type S3Buckets []*S3Bucket
@@ -48,8 +56,12 @@ type Statement struct {
 }
 
 type Condition struct {
-	Bool map[string]string `json:",omitempty"`
-	Null map[string]string `json:",omitempty"`
+	Bool            map[string]string `json:",omitempty"`
+	Null            map[string]string `json:",omitempty"`
+	StringEquals    map[string]string `json:",omitempty"`
+	StringLike      map[string]string `json:",omitempty"`
+	IpAddress       map[string]string `json:",omitempty"`
+	NumericLessThan map[string]string `json:",omitempty"`
 }
 
 type Actions []string
@@ -112,42 +124,50 @@ func (p *Principal) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func (b *S3Buckets) LoadRegions(sess *session.Session) error {
+// defaultMaxConcurrency bounds the worker pool when config doesn't set its
+// own MaxConcurrency.
+const defaultMaxConcurrency = 10
+
+// poolConcurrency returns the worker pool size to use for per-bucket API
+// fan-out: config.MaxConcurrency when positive, otherwise defaultMaxConcurrency.
+func poolConcurrency(config *configuration.Config) int {
+	if config != nil && config.MaxConcurrency > 0 {
+		return config.MaxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// LoadRegions fetches each bucket's region through the shared worker pool,
+// retrying throttled calls with backoff instead of firing one unbounded
+// goroutine per bucket.
+func (b *S3Buckets) LoadRegions(sess *session.Session, config *configuration.Config) error {
 	sess.Handlers.Unmarshal.PushBackNamed(s3.NormalizeBucketLocationHandler)
 	s3API := s3.New(sess)
 
-	wg := sync.WaitGroup{}
-	n := len(*b)
-	wg.Add(n)
-	done := make(chan bool, n)
-	cerrs := make(chan error, n)
-
-	go func() {
-		wg.Wait()
-		close(done)
-		close(cerrs)
-	}()
+	p := pool.New(poolConcurrency(config))
+	retryCfg := pool.DefaultRetryConfig()
+	g, ctx := errgroup.WithContext(context.Background())
 
 	for _, bucket := range *b {
-		go func(s3Bucket *S3Bucket) {
-			result, err := s3API.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: s3Bucket.Name})
+		bucket := bucket
+		g.Go(func() error {
+			err := p.Do(ctx, func() error {
+				return pool.Retry(ctx, retryCfg, func() error {
+					result, err := s3API.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: bucket.Name})
+					if err != nil {
+						return err
+					}
+					bucket.Region = result.LocationConstraint
+					return nil
+				})
+			})
 			if err != nil {
-				cerrs <- err
-				return
+				return fmt.Errorf("[ERROR] %s: %s", *bucket.Name, err.Error())
 			}
-			s3Bucket.Region = result.LocationConstraint
-			done <- true
-		}(bucket)
+			return nil
+		})
 	}
-	for i := 0; i < n; i++ {
-		select {
-		case <-done:
-		case err := <-cerrs:
-			return err
-		}
-	}
-
-	return nil
+	return g.Wait()
 }
 
 // LoadNames : Get All S3 Bucket names
@@ -182,21 +202,37 @@ func getRegionMapOfS3APIs(s3Buckets S3Buckets, config *configuration.Config) (ma
 				return nil, err
 			}
 		}
-		// TODO : Add some check to stop iteration
-		// if len(regionS3APIs) >= 17 {
-		// 	break
-		// }
 	}
 	return regionS3APIs, nil
 }
 
+// s3BucketLoader populates one piece of S3Bucket state from a per-region S3
+// API client. Every loader is run once per bucket through the shared worker
+// pool, wrapped in the decorrelated-jitter retry so a thrown Throttling or
+// SlowDown error doesn't fail the whole scan.
+type s3BucketLoader func(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error
+
+// s3BucketLoaders lists every per-bucket loader LoadFromAWS fans out to.
+// Add new loaders here; nothing else in LoadFromAWS needs to change.
+var s3BucketLoaders = []s3BucketLoader{
+	getPolicy,
+	getEncryption,
+	getBucketLogging,
+	getPublicAccessBlock,
+	getBucketACL,
+	getBucketVersioning,
+	getObjectLockConfiguration,
+	getReplicationConfiguration,
+	getBucketTagging,
+}
+
 func (b *S3Buckets) LoadFromAWS(sess *session.Session, config *configuration.Config) error {
 	err := b.LoadNames(sess)
 	if err != nil {
 		return err
 	}
 
-	err = b.LoadRegions(sess)
+	err = b.LoadRegions(sess, config)
 	if err != nil {
 		return err
 	}
@@ -206,94 +242,153 @@ func (b *S3Buckets) LoadFromAWS(sess *session.Session, config *configuration.Con
 		return err
 	}
 
-	var wg sync.WaitGroup
-	n := 3 * len(*b)
-	done := make(chan bool, n)
-	errs := make(chan error, n)
-	wg.Add(n)
-
-	go func() {
-		wg.Wait()
-		close(done)
-		close(errs)
-	}()
+	p := pool.New(poolConcurrency(config))
+	retryCfg := pool.DefaultRetryConfig()
+	g, ctx := errgroup.WithContext(context.Background())
 
 	for _, s3Bucket := range *b {
+		s3Bucket := s3Bucket
 		regionS3API := regionS3APIs[*s3Bucket.Region]
-		go getPolicy(s3Bucket, regionS3API, done, errs, &wg)
-		go getEncryption(s3Bucket, regionS3API, done, errs, &wg)
-		go getBucketLogging(s3Bucket, regionS3API, done, errs, &wg)
-	}
-	for i := 0; i < n; i++ {
-		select {
-		case <-done:
-		case err := <-errs:
-			return err
+		for _, loader := range s3BucketLoaders {
+			loader := loader
+			g.Go(func() error {
+				return loader(ctx, s3Bucket, regionS3API, p, retryCfg)
+			})
 		}
 	}
+	return g.Wait()
+}
+
+// withRetry runs fn through the pool and the decorrelated-jitter retry,
+// wrapping any final error with the bucket name for context.
+func withRetry(ctx context.Context, s3Bucket *S3Bucket, p *pool.Pool, retryCfg pool.RetryConfig, fn func() error) error {
+	err := p.Do(ctx, func() error {
+		return pool.Retry(ctx, retryCfg, fn)
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] %s: %s", *s3Bucket.Name, err.Error())
+	}
 	return nil
 }
 
-func getPolicy(s3Bucket *S3Bucket, s3API *s3.S3, done chan bool, errc chan error, wg *sync.WaitGroup) {
-	defer wg.Done()
+func getPolicy(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchBucketPolicy" {
+				return nil
+			}
+			return err
+		}
+		if result.Policy != nil {
+			s3Bucket.S3Policy, err = NewS3Policy(*result.Policy)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	result, err := s3API.GetBucketPolicy(&s3.GetBucketPolicyInput{
-		Bucket: s3Bucket.Name,
+func getEncryption(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ServerSideEncryptionConfigurationNotFoundError" {
+				return nil
+			}
+			return err
+		}
+		s3Bucket.ServerSideEncryptionConfiguration = result.ServerSideEncryptionConfiguration
+		return nil
 	})
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case "NoSuchBucketPolicy":
-				done <- true
-			default:
-				errc <- fmt.Errorf("[AWS-ERROR] Bucket: %s  Error Msg: %s", *s3Bucket.Name, aerr.Error())
+}
+
+func getBucketLogging(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketLogging(&s3.GetBucketLoggingInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			return err
+		}
+		s3Bucket.LoggingEnabled = result.LoggingEnabled
+		return nil
+	})
+}
+
+func getPublicAccessBlock(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchPublicAccessBlockConfiguration" {
+				return nil
 			}
-		} else {
-			errc <- fmt.Errorf("[ERROR] %s: %s", *s3Bucket.Name, err.Error())
+			return err
 		}
-		return
-	}
-	if result.Policy != nil {
-		s3Bucket.S3Policy, err = NewS3Policy(*result.Policy)
+		s3Bucket.PublicAccessBlockConfiguration = result.PublicAccessBlockConfiguration
+		return nil
+	})
+}
+
+func getBucketACL(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketAcl(&s3.GetBucketAclInput{Bucket: s3Bucket.Name})
 		if err != nil {
-			errc <- fmt.Errorf("[ERROR] Bucket: %s Error Msg: %s", *s3Bucket.Name, err.Error())
-			return
+			return err
 		}
-	}
-	done <- true
+		s3Bucket.ACL = result
+		return nil
+	})
 }
 
-func getEncryption(s3Bucket *S3Bucket, s3API *s3.S3, done chan bool, errs chan error, wg *sync.WaitGroup) {
-	defer wg.Done()
-	result, err := s3API.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: s3Bucket.Name})
+func getBucketVersioning(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketVersioning(&s3.GetBucketVersioningInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			return err
+		}
+		s3Bucket.Versioning = result
+		return nil
+	})
+}
 
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case "ServerSideEncryptionConfigurationNotFoundError":
-				done <- true
-			default:
-				errs <- fmt.Errorf("[AWS-ERROR] \nBucket: %s \n Error Msg: %s", *s3Bucket.Name, aerr.Error())
+func getObjectLockConfiguration(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ObjectLockConfigurationNotFoundError" {
+				return nil
 			}
-		} else {
-			errs <- fmt.Errorf("[ERROR] %s: %s", *s3Bucket.Name, err.Error())
+			return err
 		}
-		return
-	}
+		s3Bucket.ObjectLockConfiguration = result.ObjectLockConfiguration
+		return nil
+	})
+}
 
-	if result.ServerSideEncryptionConfiguration != nil {
-		s3Bucket.ServerSideEncryptionConfiguration = result.ServerSideEncryptionConfiguration
-	}
-	done <- true
+func getReplicationConfiguration(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketReplication(&s3.GetBucketReplicationInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ReplicationConfigurationNotFoundError" {
+				return nil
+			}
+			return err
+		}
+		s3Bucket.ReplicationConfiguration = result.ReplicationConfiguration
+		return nil
+	})
 }
 
-func getBucketLogging(s3Bucket *S3Bucket, s3API *s3.S3, done chan bool, errs chan error, wg *sync.WaitGroup) {
-	defer wg.Done()
-	result, err := s3API.GetBucketLogging(&s3.GetBucketLoggingInput{Bucket: s3Bucket.Name})
-	if err != nil {
-		errs <- fmt.Errorf("[ERROR] %s: %s", *s3Bucket.Name, err.Error())
-		return
-	}
-	s3Bucket.LoggingEnabled = result.LoggingEnabled
-	done <- true
+func getBucketTagging(ctx context.Context, s3Bucket *S3Bucket, s3API *s3.S3, p *pool.Pool, retryCfg pool.RetryConfig) error {
+	return withRetry(ctx, s3Bucket, p, retryCfg, func() error {
+		result, err := s3API.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: s3Bucket.Name})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchTagSet" {
+				return nil
+			}
+			return err
+		}
+		s3Bucket.Tags = result.TagSet
+		return nil
+	})
 }